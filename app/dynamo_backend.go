@@ -1,8 +1,10 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"strconv"
 	"time"
 
@@ -31,10 +33,26 @@ func BuildDynamodbClient() *dynamodb.DynamoDB {
 }
 
 type Backend interface {
-	Acquire(name string, nonce string, duration time.Duration) (*Acquisition, error)
-	UpdateValue(name string, fence int64, extension time.Duration, value string) error
-	Heartbeat(name string, fence int64, extension time.Duration) error
-	Release(name string, fence int64) error
+	// Acquire attempts to acquire the lock. If it is currently held and wait
+	// is non-zero, Acquire blocks (up to wait) for the current holder to
+	// release or expire before giving up. ctx governs the whole call,
+	// including any waiting.
+	Acquire(ctx context.Context, name string, nonce string, duration time.Duration, wait time.Duration) (*Acquisition, error)
+	// AcquireMulti attempts to acquire every key in keys atomically: either
+	// all succeed or none do, so a caller coordinating several resources at
+	// once never observes partial ownership.
+	AcquireMulti(ctx context.Context, keys []string, nonce string, duration time.Duration) (map[string]*Acquisition, error)
+	UpdateValue(ctx context.Context, name string, fence int64, extension time.Duration, value string) error
+	Heartbeat(ctx context.Context, name string, fence int64, extension time.Duration) error
+	Release(ctx context.Context, name string, fence int64) error
+	// Peek returns the current state of a lock without mutating it, or nil if
+	// the lock is not held. It backs WatchLock polling over gRPC.
+	Peek(ctx context.Context, name string) (*Acquisition, error)
+	// WasReleased reports whether fence was explicitly given up via Release,
+	// as opposed to simply expiring. Peek alone can only say a lock isn't
+	// currently held, not why, so WatchLock calls this to tell a clean
+	// release apart from a lease timeout.
+	WasReleased(ctx context.Context, name string, fence int64) (bool, error)
 }
 
 type DynamoBackend struct {
@@ -77,11 +95,136 @@ func (e ExpectedError) Error() string {
 	return e.Cause.Error()
 }
 
-func (t *DynamoBackend) Acquire(name string, nonce string, duration time.Duration) (*Acquisition, error) {
+// pollInterval is the base delay between Acquire retries while waiting on a
+// contended lock. Actual delay is jittered to avoid a thundering herd of
+// waiters retrying in lockstep.
+const pollInterval = 200 * time.Millisecond
+
+// Acquire attempts to acquire the lock, and if it is currently held, polls
+// with jittered backoff until it becomes available or wait elapses.
+func (t *DynamoBackend) Acquire(ctx context.Context, name string, nonce string, duration time.Duration, wait time.Duration) (*Acquisition, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		acq, err := t.tryAcquire(ctx, name, nonce, duration)
+		if err == nil {
+			return acq, nil
+		}
+		if _, ok := err.(*ExpectedError); !ok {
+			return nil, err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, err
+		}
+		sleep := time.Duration(rand.Int63n(int64(pollInterval)))
+		if sleep > remaining {
+			sleep = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sleep):
+		}
+	}
+}
+
+// AcquireMulti acquires every key or none via TransactWriteItems, with a
+// conditional Update per key mirroring tryAcquire's condition expression. If
+// any key is contended, DynamoDB cancels the whole transaction and no key's
+// state changes.
+func (t *DynamoBackend) AcquireMulti(ctx context.Context, keys []string, nonce string, duration time.Duration) (map[string]*Acquisition, error) {
+	now := time.Now()
+	expiry := now.Add(duration)
+
+	items := make([]*dynamodb.TransactWriteItem, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, &dynamodb.TransactWriteItem{
+			Update: &dynamodb.Update{
+				TableName: awsString(t.Table),
+				Key: map[string]*dynamodb.AttributeValue{
+					"LockName": {S: awsString(key)},
+				},
+				ConditionExpression: awsString("attribute_not_exists(LockName) OR ExpireTime < :now OR Nonce = :nonce"),
+				UpdateExpression:    awsString("SET Nonce = :nonce, Fence = if_not_exists(Fence, :zero) + :one, AcquireTime = :now, HeartbeatTime = :now, ExpireTime = :expire"),
+				ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+					":one":    {N: awsInt(1)},
+					":zero":   {N: awsInt(0)},
+					":now":    {N: awsTime(now)},
+					":expire": {N: awsTime(expiry)},
+					":nonce":  {S: awsString(nonce)},
+				},
+			},
+		})
+	}
+
+	_, err := t.Db.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			if aerr.Code() == dynamodb.ErrCodeTransactionCanceledException {
+				return nil, &ExpectedError{errors.New("Lock in use")}
+			}
+		}
+		return nil, err
+	}
+
+	// TransactWriteItems can't return the new attribute values the way a
+	// single UpdateItem with ReturnValues: "ALL_NEW" can, so Fence and Body
+	// (the two fields we didn't already compute client-side above) have to
+	// be read back. That read must not go through Peek: Peek treats an item
+	// as absent once its ExpireTime has passed, and a short-lived lease can
+	// race past its own expiry before this read happens, which would
+	// otherwise turn a successful acquire into a nil result.
+	results := make(map[string]*Acquisition, len(keys))
+	for _, key := range keys {
+		fence, body, err := t.fetchFenceAndBody(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		results[key] = &Acquisition{
+			AcquireTime: now,
+			ExpireTime:  expiry,
+			Fence:       fence,
+			Body:        body,
+		}
+	}
+	return results, nil
+}
+
+// fetchFenceAndBody reads back the Fence and Body the transaction in
+// AcquireMulti just wrote, without Peek's expiry filtering -- the caller
+// already knows the lock was just acquired and supplies its own
+// AcquireTime/ExpireTime.
+func (t *DynamoBackend) fetchFenceAndBody(ctx context.Context, name string) (int64, string, error) {
+	rec, err := t.Db.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(t.Table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockName": {S: awsString(name)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	if rec.Item == nil {
+		return 0, "", fmt.Errorf("No item %s found immediately after acquiring it", name)
+	}
+
+	fence, err := extractInt(rec.Item, "Fence")
+	if err != nil {
+		return 0, "", err
+	}
+	body, _ := extractString(rec.Item, "Body")
+
+	return fence, body, nil
+}
+
+func (t *DynamoBackend) tryAcquire(ctx context.Context, name string, nonce string, duration time.Duration) (*Acquisition, error) {
 	now := time.Now()
 	expiry := now.Add(duration)
 
-	rec, err := t.Db.UpdateItem(&dynamodb.UpdateItemInput{
+	rec, err := t.Db.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			"LockName": {S: awsString(name)},
 		},
@@ -151,17 +294,17 @@ func extractInt(attrs map[string]*dynamodb.AttributeValue, key string) (int64, e
 	return valInt, err
 }
 
-func (t *DynamoBackend) Heartbeat(name string, fence int64, extension time.Duration) error {
+func (t *DynamoBackend) Heartbeat(ctx context.Context, name string, fence int64, extension time.Duration) error {
 	now := time.Now()
 	expire := now.Add(extension)
 
-	_, err := t.Db.UpdateItem(&dynamodb.UpdateItemInput{
+	_, err := t.Db.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(t.Table),
 		Key: map[string]*dynamodb.AttributeValue{
 			"LockName": {S: awsString(name)},
 		},
 		ConditionExpression: awsString("attribute_exists(LockName) AND ExpireTime > :now AND Fence = :fence"),
-		UpdateExpression:    awsString("SET HeartbeatTime :now, ExpireTime = :expire"),
+		UpdateExpression:    awsString("SET HeartbeatTime = :now, ExpireTime = :expire"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":fence":  {N: awsInt(fence)},
 			":now":    {N: awsTime(now)},
@@ -180,11 +323,11 @@ func (t *DynamoBackend) Heartbeat(name string, fence int64, extension time.Durat
 	return nil
 }
 
-func (t *DynamoBackend) UpdateValue(name string, fence int64, extension time.Duration, value string) error {
+func (t *DynamoBackend) UpdateValue(ctx context.Context, name string, fence int64, extension time.Duration, value string) error {
 	now := time.Now()
 	expire := now.Add(extension)
 
-	_, err := t.Db.UpdateItem(&dynamodb.UpdateItemInput{
+	_, err := t.Db.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(t.Table),
 		Key: map[string]*dynamodb.AttributeValue{
 			"LockName": {S: awsString(name)},
@@ -209,8 +352,51 @@ func (t *DynamoBackend) UpdateValue(name string, fence int64, extension time.Dur
 	return nil
 }
 
-func (t *DynamoBackend) Release(name string, fence int64) error {
-	_, err := t.Db.UpdateItem(&dynamodb.UpdateItemInput{
+func (t *DynamoBackend) Peek(ctx context.Context, name string) (*Acquisition, error) {
+	rec, err := t.Db.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(t.Table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockName": {S: awsString(name)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rec.Item == nil {
+		return nil, nil
+	}
+
+	expTime, err := extractInt(rec.Item, "ExpireTime")
+	if err != nil {
+		return nil, err
+	}
+	if time.Unix(0, expTime).Before(time.Now()) {
+		return nil, nil
+	}
+
+	acqTime, err := extractInt(rec.Item, "AcquireTime")
+	if err != nil {
+		return nil, err
+	}
+
+	fence, err := extractInt(rec.Item, "Fence")
+	if err != nil {
+		return nil, err
+	}
+
+	body, _ := extractString(rec.Item, "Body")
+
+	return &Acquisition{
+		AcquireTime: time.Unix(0, acqTime),
+		ExpireTime:  time.Unix(0, expTime),
+		Fence:       fence,
+		Body:        body,
+	}, nil
+}
+
+func (t *DynamoBackend) Release(ctx context.Context, name string, fence int64) error {
+	_, err := t.Db.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
 		TableName: aws.String(t.Table),
 		Key: map[string]*dynamodb.AttributeValue{
 			"LockName": {S: awsString(name)},
@@ -218,7 +404,8 @@ func (t *DynamoBackend) Release(name string, fence int64) error {
 		ConditionExpression: awsString("attribute_exists(LockName) AND Fence = :fence"),
 		UpdateExpression:    awsString("SET ExpireTime = :zero"),
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
-			":zero": {N: awsInt(0)},
+			":zero":  {N: awsInt(0)},
+			":fence": {N: awsInt(fence)},
 		},
 	})
 	if err != nil {
@@ -234,6 +421,39 @@ func (t *DynamoBackend) Release(name string, fence int64) error {
 	return nil
 }
 
+// WasReleased reports whether fence is the one Release last zeroed
+// ExpireTime for. Release never deletes the item, only sets ExpireTime to 0
+// while leaving Fence as-is, so an item with a past ExpireTime is otherwise
+// ambiguous between "explicitly released" and "simply expired" -- ExpireTime
+// == 0 is the tombstone Release leaves, while a nonzero-but-past ExpireTime
+// means the lease just ran out.
+func (t *DynamoBackend) WasReleased(ctx context.Context, name string, fence int64) (bool, error) {
+	rec, err := t.Db.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: awsString(t.Table),
+		Key: map[string]*dynamodb.AttributeValue{
+			"LockName": {S: awsString(name)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return false, err
+	}
+	if rec.Item == nil {
+		return false, nil
+	}
+
+	itemFence, err := extractInt(rec.Item, "Fence")
+	if err != nil || itemFence != fence {
+		return false, nil
+	}
+
+	expTime, err := extractInt(rec.Item, "ExpireTime")
+	if err != nil {
+		return false, nil
+	}
+	return expTime == 0, nil
+}
+
 func awsTime(t time.Time) *string {
 	return aws.String(fmt.Sprintf("%d", t.UnixNano()))
 }
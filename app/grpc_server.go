@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/twooster/glock/glockpb"
+)
+
+// watchPollInterval is how often WatchLock re-checks lock state. Backends
+// don't natively push changes, so this is a simple poll loop rather than a
+// subscription.
+const watchPollInterval = 500 * time.Millisecond
+
+// GrpcServer implements glockpb.GlockServer against a Backend, so gRPC and
+// HTTP clients share the exact same locking semantics and state.
+type GrpcServer struct {
+	glockpb.UnimplementedGlockServer
+	backend Backend
+}
+
+func NewGrpcServer(backend Backend) *GrpcServer {
+	return &GrpcServer{backend: backend}
+}
+
+func (g *GrpcServer) Acquire(ctx context.Context, req *glockpb.AcquireRequest) (*glockpb.AcquireResponse, error) {
+	acq, err := g.backend.Acquire(ctx, req.Key, req.Nonce, time.Duration(req.LeaseMillis)*time.Millisecond, time.Duration(req.WaitMillis)*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	return &glockpb.AcquireResponse{
+		AcquireTime: acq.AcquireTime.UnixNano(),
+		ExpireTime:  acq.ExpireTime.UnixNano(),
+		Fence:       acq.Fence,
+		Body:        acq.Body,
+	}, nil
+}
+
+func (g *GrpcServer) Heartbeat(ctx context.Context, req *glockpb.HeartbeatRequest) (*glockpb.HeartbeatResponse, error) {
+	if err := g.backend.Heartbeat(ctx, req.Key, req.Fence, time.Duration(req.LeaseMillis)*time.Millisecond); err != nil {
+		return nil, err
+	}
+	return &glockpb.HeartbeatResponse{}, nil
+}
+
+func (g *GrpcServer) UpdateValue(ctx context.Context, req *glockpb.UpdateValueRequest) (*glockpb.UpdateValueResponse, error) {
+	if err := g.backend.UpdateValue(ctx, req.Key, req.Fence, time.Duration(req.LeaseMillis)*time.Millisecond, req.Value); err != nil {
+		return nil, err
+	}
+	return &glockpb.UpdateValueResponse{}, nil
+}
+
+func (g *GrpcServer) Release(ctx context.Context, req *glockpb.ReleaseRequest) (*glockpb.ReleaseResponse, error) {
+	if err := g.backend.Release(ctx, req.Key, req.Fence); err != nil {
+		return nil, err
+	}
+	return &glockpb.ReleaseResponse{}, nil
+}
+
+// WatchLock streams acquired/heartbeat/released/expired events for a key by
+// polling Peek and diffing against the last observed state. When a held
+// lock goes away, it asks the backend's WasReleased to tell a clean Release
+// apart from a lease that simply ran out, since Peek alone only reports
+// whether a lock is currently held, not why it stopped being held.
+func (g *GrpcServer) WatchLock(req *glockpb.WatchLockRequest, stream glockpb.Glock_WatchLockServer) error {
+	var lastFence int64 = -1
+	var lastExpireTime time.Time
+	var held bool
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		acq, err := g.backend.Peek(stream.Context(), req.Key)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case acq == nil && held:
+			held = false
+			kind := "expired"
+			if released, err := g.backend.WasReleased(stream.Context(), req.Key, lastFence); err != nil {
+				return err
+			} else if released {
+				kind = "released"
+			}
+			if err := stream.Send(&glockpb.LockEvent{Kind: kind, Fence: lastFence}); err != nil {
+				return err
+			}
+		case acq != nil && acq.Fence != lastFence:
+			held = true
+			lastFence = acq.Fence
+			lastExpireTime = acq.ExpireTime
+			if err := stream.Send(&glockpb.LockEvent{
+				Kind:       "acquired",
+				Fence:      acq.Fence,
+				ExpireTime: acq.ExpireTime.UnixNano(),
+				Body:       acq.Body,
+			}); err != nil {
+				return err
+			}
+		case acq != nil && held && acq.ExpireTime.After(lastExpireTime):
+			lastExpireTime = acq.ExpireTime
+			if err := stream.Send(&glockpb.LockEvent{
+				Kind:       "heartbeat",
+				Fence:      acq.Fence,
+				ExpireTime: acq.ExpireTime.UnixNano(),
+				Body:       acq.Body,
+			}); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InstrumentedBackend wraps a Backend, recording Prometheus counters and
+// histograms for every operation and maintaining a gauge of locks believed
+// held by this instance. It otherwise delegates straight through, so any
+// Backend can be made observable by wrapping it once at construction time.
+//
+// The gauge is tracked against a local fence map rather than just incrementing
+// on every successful Acquire/decrementing on every successful Release,
+// since a re-acquire by the same nonce (a lease refresh) isn't a new lock and
+// an idempotent release of a lock this instance never held isn't a released
+// one -- both would otherwise skew locksHeld away from reality.
+type InstrumentedBackend struct {
+	Backend
+
+	mu    sync.Mutex
+	fence map[string]int64
+}
+
+func NewInstrumentedBackend(backend Backend) *InstrumentedBackend {
+	return &InstrumentedBackend{
+		Backend: backend,
+		fence:   make(map[string]int64),
+	}
+}
+
+// noteHeldLocked records that name is now held at fence, incrementing
+// locksHeld only if this instance didn't already believe it held name.
+// Callers must hold b.mu.
+func (b *InstrumentedBackend) noteHeldLocked(name string, fence int64) {
+	if _, ok := b.fence[name]; !ok {
+		locksHeld.Inc()
+	}
+	b.fence[name] = fence
+}
+
+// noteReleasedLocked forgets name, decrementing locksHeld only if this
+// instance believed it held it at fence. Callers must hold b.mu.
+func (b *InstrumentedBackend) noteReleasedLocked(name string, fence int64) {
+	if held, ok := b.fence[name]; ok && held == fence {
+		delete(b.fence, name)
+		locksHeld.Dec()
+	}
+}
+
+func (b *InstrumentedBackend) Acquire(ctx context.Context, name string, nonce string, duration time.Duration, wait time.Duration) (*Acquisition, error) {
+	start := time.Now()
+	acq, err := b.Backend.Acquire(ctx, name, nonce, duration, wait)
+	observeOp("acquire", start, err)
+	if err == nil {
+		b.mu.Lock()
+		b.noteHeldLocked(name, acq.Fence)
+		b.mu.Unlock()
+	}
+	return acq, err
+}
+
+func (b *InstrumentedBackend) AcquireMulti(ctx context.Context, keys []string, nonce string, duration time.Duration) (map[string]*Acquisition, error) {
+	start := time.Now()
+	acqs, err := b.Backend.AcquireMulti(ctx, keys, nonce, duration)
+	observeOp("acquire_multi", start, err)
+	if err == nil {
+		b.mu.Lock()
+		for key, acq := range acqs {
+			b.noteHeldLocked(key, acq.Fence)
+		}
+		b.mu.Unlock()
+	}
+	return acqs, err
+}
+
+func (b *InstrumentedBackend) Heartbeat(ctx context.Context, name string, fence int64, extension time.Duration) error {
+	start := time.Now()
+	err := b.Backend.Heartbeat(ctx, name, fence, extension)
+	observeOp("heartbeat", start, err)
+	return err
+}
+
+func (b *InstrumentedBackend) UpdateValue(ctx context.Context, name string, fence int64, extension time.Duration, value string) error {
+	start := time.Now()
+	err := b.Backend.UpdateValue(ctx, name, fence, extension, value)
+	observeOp("update", start, err)
+	return err
+}
+
+func (b *InstrumentedBackend) Release(ctx context.Context, name string, fence int64) error {
+	start := time.Now()
+	err := b.Backend.Release(ctx, name, fence)
+	observeOp("release", start, err)
+	if err == nil {
+		b.mu.Lock()
+		b.noteReleasedLocked(name, fence)
+		b.mu.Unlock()
+	}
+	return err
+}
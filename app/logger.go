@@ -0,0 +1,53 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Logger is the structured logger used for both request logging within this
+// package and general server lifecycle logging from main.go.
+var Logger *zap.Logger
+
+func init() {
+	l, err := zap.NewProduction()
+	if err != nil {
+		l = zap.NewNop()
+	}
+	Logger = l
+}
+
+// hashNonce returns a short, non-reversible identifier for a nonce so it can
+// appear in logs without leaking the value a client uses to prove lock
+// ownership.
+func hashNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// logRequest records a single lock operation: key, fence, hashed nonce, and
+// latency, at info level on success and warn level on failure. fence and
+// nonce are omitted from the log when zero/empty (not every operation has
+// one at call time).
+func logRequest(op string, key string, fence int64, nonce string, start time.Time, err error) {
+	fields := []zap.Field{
+		zap.String("op", op),
+		zap.String("key", key),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if fence != 0 {
+		fields = append(fields, zap.Int64("fence", fence))
+	}
+	if nonce != "" {
+		fields = append(fields, zap.String("nonce", hashNonce(nonce)))
+	}
+
+	if err != nil {
+		Logger.Warn("lock operation failed", append(fields, zap.Error(err))...)
+		return
+	}
+	Logger.Info("lock operation succeeded", fields...)
+}
@@ -0,0 +1,253 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// memoryExpiryCheckInterval bounds how long a waiter in Acquire can go
+// between checks, since nothing proactively wakes it when a lease merely
+// expires (only an explicit Release does).
+const memoryExpiryCheckInterval = 500 * time.Millisecond
+
+// memoryLockState mirrors the fields the DynamoDB and Redis backends persist
+// per lock: nonce, fence, timestamps, and the opaque body.
+type memoryLockState struct {
+	Nonce       string
+	Fence       int64
+	AcquireTime time.Time
+	ExpireTime  time.Time
+	Body        string
+}
+
+// MemoryBackend implements Backend with a mutex-guarded in-memory map. It's a
+// zero-dependency option for local development and for exercising the HTTP
+// and gRPC layers in tests without a real DynamoDB or Redis.
+//
+// Expired entries aren't swept by a background goroutine; they're simply
+// treated as absent the next time they're looked at, which is all a
+// single-process backend needs.
+type MemoryBackend struct {
+	mu       sync.Mutex
+	locks    map[string]*memoryLockState
+	fences   map[string]int64
+	waiters  map[string]chan struct{}
+	released map[string]int64
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		locks:    make(map[string]*memoryLockState),
+		fences:   make(map[string]int64),
+		waiters:  make(map[string]chan struct{}),
+		released: make(map[string]int64),
+	}
+}
+
+// waitChanLocked returns the channel that will be closed the next time name
+// is released, creating it if necessary. Callers must hold b.mu.
+func (b *MemoryBackend) waitChanLocked(name string) chan struct{} {
+	ch, ok := b.waiters[name]
+	if !ok {
+		ch = make(chan struct{})
+		b.waiters[name] = ch
+	}
+	return ch
+}
+
+// notifyLocked wakes everyone waiting on name's release. Callers must hold
+// b.mu.
+func (b *MemoryBackend) notifyLocked(name string) {
+	if ch, ok := b.waiters[name]; ok {
+		close(ch)
+		delete(b.waiters, name)
+	}
+}
+
+// Acquire attempts to acquire the lock, and if it is currently held, waits
+// (up to wait) on a per-key channel that Release closes, re-checking at
+// memoryExpiryCheckInterval in case the holder's lease simply expired.
+func (b *MemoryBackend) Acquire(ctx context.Context, name string, nonce string, duration time.Duration, wait time.Duration) (*Acquisition, error) {
+	deadline := time.Now().Add(wait)
+
+	for {
+		acq, err := b.tryAcquire(name, nonce, duration)
+		if err == nil {
+			return acq, nil
+		}
+		if _, ok := err.(*ExpectedError); !ok {
+			return nil, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, err
+		}
+
+		b.mu.Lock()
+		ch := b.waitChanLocked(name)
+		b.mu.Unlock()
+
+		wakeup := memoryExpiryCheckInterval
+		if wakeup > remaining {
+			wakeup = remaining
+		}
+		timer := time.NewTimer(wakeup)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-ch:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (b *MemoryBackend) tryAcquire(name string, nonce string, duration time.Duration) (*Acquisition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	existing := b.locks[name]
+	if existing != nil && existing.ExpireTime.After(now) && existing.Nonce != nonce {
+		return nil, &ExpectedError{errors.New("Lock in use")}
+	}
+
+	body := ""
+	if existing != nil {
+		body = existing.Body
+	}
+
+	b.fences[name]++
+	state := &memoryLockState{
+		Nonce:       nonce,
+		Fence:       b.fences[name],
+		AcquireTime: now,
+		ExpireTime:  now.Add(duration),
+		Body:        body,
+	}
+	b.locks[name] = state
+
+	return &Acquisition{
+		AcquireTime: state.AcquireTime,
+		ExpireTime:  state.ExpireTime,
+		Fence:       state.Fence,
+		Body:        state.Body,
+	}, nil
+}
+
+// AcquireMulti acquires every key in keys or none: it checks all of them
+// against the same held-by-someone-else condition as tryAcquire before
+// mutating any of them, all under a single lock acquisition so no other
+// caller can observe a partial result.
+func (b *MemoryBackend) AcquireMulti(ctx context.Context, keys []string, nonce string, duration time.Duration) (map[string]*Acquisition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for _, key := range keys {
+		existing := b.locks[key]
+		if existing != nil && existing.ExpireTime.After(now) && existing.Nonce != nonce {
+			return nil, &ExpectedError{errors.New("Lock in use")}
+		}
+	}
+
+	results := make(map[string]*Acquisition, len(keys))
+	for _, key := range keys {
+		existing := b.locks[key]
+		body := ""
+		if existing != nil {
+			body = existing.Body
+		}
+
+		b.fences[key]++
+		state := &memoryLockState{
+			Nonce:       nonce,
+			Fence:       b.fences[key],
+			AcquireTime: now,
+			ExpireTime:  now.Add(duration),
+			Body:        body,
+		}
+		b.locks[key] = state
+		results[key] = &Acquisition{
+			AcquireTime: state.AcquireTime,
+			ExpireTime:  state.ExpireTime,
+			Fence:       state.Fence,
+			Body:        state.Body,
+		}
+	}
+	return results, nil
+}
+
+func (b *MemoryBackend) Heartbeat(ctx context.Context, name string, fence int64, extension time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing := b.locks[name]
+	if existing == nil || existing.Fence != fence || !existing.ExpireTime.After(time.Now()) {
+		return &ExpectedError{errors.New("Lock expired")}
+	}
+	existing.ExpireTime = time.Now().Add(extension)
+	return nil
+}
+
+func (b *MemoryBackend) UpdateValue(ctx context.Context, name string, fence int64, extension time.Duration, value string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing := b.locks[name]
+	if existing == nil || existing.Fence != fence || !existing.ExpireTime.After(time.Now()) {
+		return &ExpectedError{errors.New("Lock expired")}
+	}
+	existing.ExpireTime = time.Now().Add(extension)
+	existing.Body = value
+	return nil
+}
+
+func (b *MemoryBackend) Release(ctx context.Context, name string, fence int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing := b.locks[name]
+	if existing == nil || existing.Fence != fence {
+		// Idempotent release -- matches the DynamoDB and Redis backends:
+		// releasing a lock you don't hold (or never held) is not an error.
+		return nil
+	}
+	delete(b.locks, name)
+	b.released[name] = fence
+	b.notifyLocked(name)
+	return nil
+}
+
+// WasReleased reports whether fence is the one Release last removed from
+// locks. It backs WatchLock's released-vs-expired distinction: locks is
+// never swept for natural expiry, so a fence that expired without an
+// explicit Release still has its (stale) entry sitting in locks, while one
+// that was released has been deleted and its fence recorded here instead.
+func (b *MemoryBackend) WasReleased(ctx context.Context, name string, fence int64) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.released[name] == fence, nil
+}
+
+func (b *MemoryBackend) Peek(ctx context.Context, name string) (*Acquisition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing := b.locks[name]
+	if existing == nil || !existing.ExpireTime.After(time.Now()) {
+		return nil, nil
+	}
+	return &Acquisition{
+		AcquireTime: existing.AcquireTime,
+		ExpireTime:  existing.ExpireTime,
+		Fence:       existing.Fence,
+		Body:        existing.Body,
+	}, nil
+}
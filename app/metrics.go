@@ -0,0 +1,66 @@
+package app
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	outcomeSuccess    = "success"
+	outcomeContention = "contention"
+	outcomeError      = "error"
+)
+
+var (
+	backendOpTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "glock_backend_op_total",
+			Help: "Count of backend lock operations, labeled by op and outcome.",
+		},
+		[]string{"op", "outcome"},
+	)
+
+	backendOpDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "glock_backend_op_duration_seconds",
+			Help:    "Latency of backend lock operations, labeled by op and outcome.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"op", "outcome"},
+	)
+
+	// locksHeld is a best-effort count of locks this instance believes it
+	// currently holds, maintained by InstrumentedBackend: incremented when an
+	// Acquire or AcquireMulti succeeds on a key it didn't already hold,
+	// decremented when a Release succeeds for the fence it holds. It does not
+	// account for leases that expire without an explicit release.
+	locksHeld = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "glock_locks_held",
+			Help: "Locks this instance has acquired and not yet released.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(backendOpTotal, backendOpDuration, locksHeld)
+}
+
+func observeOp(op string, start time.Time, err error) {
+	outcome := outcomeForErr(err)
+	backendOpTotal.WithLabelValues(op, outcome).Inc()
+	backendOpDuration.WithLabelValues(op, outcome).Observe(time.Since(start).Seconds())
+}
+
+func outcomeForErr(err error) string {
+	if err == nil {
+		return outcomeSuccess
+	}
+	switch err.(type) {
+	case ExpectedError, *ExpectedError:
+		return outcomeContention
+	default:
+		return outcomeError
+	}
+}
@@ -0,0 +1,377 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func BuildRedisClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+}
+
+// RedisBackend implements Backend on top of Redis, using key expiry (PX) to
+// mirror DynamoDB's ExpireTime check and Lua scripts to keep fence/nonce
+// comparisons atomic with the mutation they guard (Redlock-style safety).
+type RedisBackend struct {
+	Client *redis.Client
+}
+
+type redisLockState struct {
+	Nonce       string `json:"nonce"`
+	Fence       int64  `json:"fence"`
+	AcquireTime int64  `json:"acquireTime"`
+	ExpireTime  int64  `json:"expireTime"`
+	Body        string `json:"body"`
+}
+
+func lockKey(name string) string {
+	return fmt.Sprintf("glock:lock:%s", name)
+}
+
+func fenceKey(name string) string {
+	return fmt.Sprintf("glock:fence:%s", name)
+}
+
+func notifyChannel(name string) string {
+	return fmt.Sprintf("glock:notify:%s", name)
+}
+
+// bodyKey holds a lock's body independent of its TTL'd lock key, the same
+// way fenceKey holds its fence counter, so the body survives the lock key
+// expiring via PX and is carried across a handoff to a new holder -- matching
+// DynamoBackend (which never touches Body on acquire) and MemoryBackend
+// (which copies existing.Body) instead of losing it whenever the key lapses
+// between holders.
+func bodyKey(name string) string {
+	return fmt.Sprintf("glock:body:%s", name)
+}
+
+// releasedKey holds the fence of the last lock explicitly given up via
+// Release, independent of the TTL'd lock key -- once PX deletes the lock
+// key, nothing else distinguishes an explicit release from the lease simply
+// running out, so WasReleased checks this instead.
+func releasedKey(name string) string {
+	return fmt.Sprintf("glock:released:%s", name)
+}
+
+// acquireScript acquires the lock if it is unheld (the key having expired via
+// PX counts as unheld) or already held by the same nonce, bumping the fence
+// via INCR on every successful acquisition, same as the DynamoDB
+// if_not_exists(Fence, :zero) + :one update. Body comes from KEYS[3] (see
+// bodyKey) rather than the lock key itself, so it survives the lock key's
+// own expiry.
+//
+// Failure is signalled as the integer 0 rather than Lua's boolean false:
+// go-redis converts a false reply to a Nil error, which would otherwise
+// surface as a raw redis.Nil instead of a typed contention result.
+var acquireScript = redis.NewScript(`
+local existing = redis.call('GET', KEYS[1])
+local body = redis.call('GET', KEYS[3]) or ''
+if existing then
+	local decoded = cjson.decode(existing)
+	if decoded.nonce ~= ARGV[1] then
+		return 0
+	end
+	body = decoded.body
+end
+local fence = redis.call('INCR', KEYS[2])
+local state = cjson.encode({nonce=ARGV[1], fence=fence, acquireTime=tonumber(ARGV[2]), expireTime=tonumber(ARGV[3]), body=body})
+redis.call('SET', KEYS[1], state, 'PX', ARGV[4])
+redis.call('SET', KEYS[3], body)
+return state
+`)
+
+// heartbeatScript, like updateValueScript and releaseScript below, compares
+// the stored fence atomically before mutating, so a heartbeat/update/release
+// from a holder that has since lost the lock (expired or fenced out by a
+// newer acquisition) is rejected (for release, ignored) rather than
+// clobbering whoever holds it now. Only the fence is compared; the stored
+// nonce is not checked.
+//
+// Like acquireScript, failure is the integer 0 rather than Lua's false, so
+// go-redis doesn't turn it into a redis.Nil error before the caller ever
+// sees it.
+var heartbeatScript = redis.NewScript(`
+local existing = redis.call('GET', KEYS[1])
+if not existing then
+	return 0
+end
+local decoded = cjson.decode(existing)
+if decoded.fence ~= tonumber(ARGV[1]) then
+	return 0
+end
+decoded.expireTime = tonumber(ARGV[2])
+redis.call('SET', KEYS[1], cjson.encode(decoded), 'PX', ARGV[3])
+return 1
+`)
+
+// updateValueScript also writes the new body to KEYS[2] (see bodyKey) so it
+// survives the lock key's own expiry, same as acquireScript reads it back.
+var updateValueScript = redis.NewScript(`
+local existing = redis.call('GET', KEYS[1])
+if not existing then
+	return 0
+end
+local decoded = cjson.decode(existing)
+if decoded.fence ~= tonumber(ARGV[1]) then
+	return 0
+end
+decoded.expireTime = tonumber(ARGV[2])
+decoded.body = ARGV[3]
+redis.call('SET', KEYS[1], cjson.encode(decoded), 'PX', ARGV[4])
+redis.call('SET', KEYS[2], ARGV[3])
+return 1
+`)
+
+// releaseScript publishes to the lock's notify channel after deleting it, so
+// waiters blocked in Acquire wake up immediately instead of waiting out the
+// next poll interval. It also records the released fence in KEYS[3] (see
+// releasedKey), so WasReleased can tell a genuine release apart from the
+// lease simply running out after the lock key itself has expired away.
+var releaseScript = redis.NewScript(`
+local existing = redis.call('GET', KEYS[1])
+if not existing then
+	return true
+end
+local decoded = cjson.decode(existing)
+if decoded.fence ~= tonumber(ARGV[1]) then
+	return true
+end
+redis.call('DEL', KEYS[1])
+redis.call('SET', KEYS[3], ARGV[1])
+redis.call('PUBLISH', KEYS[2], 'released')
+return true
+`)
+
+// acquireMultiScript acquires every key or none. KEYS is the lock keys,
+// followed by their fence keys, followed by their body keys (in the same
+// order as the other two groups), so #KEYS/3 keys are actually being locked.
+// It first checks every lock key against the OR condition acquireScript
+// uses, and only mutates anything once all of them pass -- matching the
+// DynamoDB TransactWriteItems all-or-nothing semantics. As in acquireScript,
+// body comes from the body key rather than the lock key itself, so it
+// survives the lock key's own expiry.
+//
+// As with acquireScript, contention is signalled as the integer 0 rather
+// than Lua's false, since go-redis maps a false reply to a Nil error.
+var acquireMultiScript = redis.NewScript(`
+local n = #KEYS / 3
+local nonce, now, expire, px = ARGV[1], ARGV[2], ARGV[3], ARGV[4]
+
+for i = 1, n do
+	local existing = redis.call('GET', KEYS[i])
+	if existing then
+		local decoded = cjson.decode(existing)
+		if decoded.nonce ~= nonce then
+			return 0
+		end
+	end
+end
+
+local states = {}
+for i = 1, n do
+	local existing = redis.call('GET', KEYS[i])
+	local body = redis.call('GET', KEYS[2 * n + i]) or ''
+	if existing then
+		body = cjson.decode(existing).body
+	end
+	local fence = redis.call('INCR', KEYS[n + i])
+	local state = cjson.encode({nonce=nonce, fence=fence, acquireTime=tonumber(now), expireTime=tonumber(expire), body=body})
+	redis.call('SET', KEYS[i], state, 'PX', px)
+	redis.call('SET', KEYS[2 * n + i], body)
+	states[i] = state
+end
+return states
+`)
+
+// AcquireMulti acquires every key in keys or none, via a single Lua script
+// so the check-then-set across all keys is atomic.
+func (r *RedisBackend) AcquireMulti(ctx context.Context, keys []string, nonce string, duration time.Duration) (map[string]*Acquisition, error) {
+	now := time.Now()
+	expiry := now.Add(duration)
+
+	redisKeys := make([]string, 0, len(keys)*3)
+	for _, key := range keys {
+		redisKeys = append(redisKeys, lockKey(key))
+	}
+	for _, key := range keys {
+		redisKeys = append(redisKeys, fenceKey(key))
+	}
+	for _, key := range keys {
+		redisKeys = append(redisKeys, bodyKey(key))
+	}
+
+	res, err := acquireMultiScript.Run(ctx, r.Client, redisKeys,
+		nonce, now.UnixNano(), expiry.UnixNano(), duration.Milliseconds()).Result()
+	if err != nil {
+		return nil, err
+	}
+	states, ok := res.([]interface{})
+	if !ok {
+		return nil, &ExpectedError{errors.New("Lock in use")}
+	}
+
+	results := make(map[string]*Acquisition, len(keys))
+	for i, key := range keys {
+		stateJSON, ok := states[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected AcquireMulti result for key %s", key)
+		}
+		var state redisLockState
+		if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
+			return nil, err
+		}
+		results[key] = &Acquisition{
+			AcquireTime: time.Unix(0, state.AcquireTime),
+			ExpireTime:  time.Unix(0, state.ExpireTime),
+			Fence:       state.Fence,
+			Body:        state.Body,
+		}
+	}
+	return results, nil
+}
+
+// expiryCheckInterval bounds how long Acquire can wait between a lease's
+// natural TTL expiry and noticing it, since Redis doesn't publish expiry
+// events without keyspace notifications enabled.
+const expiryCheckInterval = 1 * time.Second
+
+// Acquire attempts to acquire the lock. If it is held and wait is non-zero,
+// it blocks (up to wait) on the key's pubsub notify channel -- woken early
+// by Release, and otherwise re-checked at expiryCheckInterval in case the
+// holder's lease simply expired.
+func (r *RedisBackend) Acquire(ctx context.Context, name string, nonce string, duration time.Duration, wait time.Duration) (*Acquisition, error) {
+	deadline := time.Now().Add(wait)
+
+	for {
+		acq, err := r.tryAcquire(ctx, name, nonce, duration)
+		if err == nil {
+			return acq, nil
+		}
+		if _, ok := err.(*ExpectedError); !ok {
+			return nil, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, err
+		}
+		wakeup := expiryCheckInterval
+		if wakeup > remaining {
+			wakeup = remaining
+		}
+
+		sub := r.Client.Subscribe(ctx, notifyChannel(name))
+		waitCtx, cancel := context.WithTimeout(ctx, wakeup)
+		_, recvErr := sub.ReceiveMessage(waitCtx)
+		cancel()
+		sub.Close()
+		if recvErr != nil && !errors.Is(recvErr, context.DeadlineExceeded) {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, recvErr
+		}
+	}
+}
+
+func (r *RedisBackend) tryAcquire(ctx context.Context, name string, nonce string, duration time.Duration) (*Acquisition, error) {
+	now := time.Now()
+	expiry := now.Add(duration)
+
+	res, err := acquireScript.Run(ctx, r.Client, []string{lockKey(name), fenceKey(name), bodyKey(name)},
+		nonce, now.UnixNano(), expiry.UnixNano(), duration.Milliseconds()).Result()
+	if err != nil {
+		return nil, err
+	}
+	stateJSON, ok := res.(string)
+	if !ok {
+		return nil, &ExpectedError{errors.New("Lock in use")}
+	}
+
+	var state redisLockState
+	if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
+		return nil, err
+	}
+
+	return &Acquisition{
+		AcquireTime: time.Unix(0, state.AcquireTime),
+		ExpireTime:  time.Unix(0, state.ExpireTime),
+		Fence:       state.Fence,
+		Body:        state.Body,
+	}, nil
+}
+
+func (r *RedisBackend) Heartbeat(ctx context.Context, name string, fence int64, extension time.Duration) error {
+	expire := time.Now().Add(extension)
+
+	res, err := heartbeatScript.Run(ctx, r.Client, []string{lockKey(name)},
+		fence, expire.UnixNano(), extension.Milliseconds()).Result()
+	if err != nil {
+		return err
+	}
+	if ok, _ := res.(int64); ok == 0 {
+		return &ExpectedError{errors.New("Lock expired")}
+	}
+	return nil
+}
+
+func (r *RedisBackend) UpdateValue(ctx context.Context, name string, fence int64, extension time.Duration, value string) error {
+	expire := time.Now().Add(extension)
+
+	res, err := updateValueScript.Run(ctx, r.Client, []string{lockKey(name), bodyKey(name)},
+		fence, expire.UnixNano(), value, extension.Milliseconds()).Result()
+	if err != nil {
+		return err
+	}
+	if ok, _ := res.(int64); ok == 0 {
+		return &ExpectedError{errors.New("Lock expired")}
+	}
+	return nil
+}
+
+func (r *RedisBackend) Release(ctx context.Context, name string, fence int64) error {
+	_, err := releaseScript.Run(ctx, r.Client, []string{lockKey(name), notifyChannel(name), releasedKey(name)}, fence).Result()
+	return err
+}
+
+func (r *RedisBackend) WasReleased(ctx context.Context, name string, fence int64) (bool, error) {
+	releasedFence, err := r.Client.Get(ctx, releasedKey(name)).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return releasedFence == strconv.FormatInt(fence, 10), nil
+}
+
+func (r *RedisBackend) Peek(ctx context.Context, name string) (*Acquisition, error) {
+	stateJSON, err := r.Client.Get(ctx, lockKey(name)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state redisLockState
+	if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
+		return nil, err
+	}
+
+	return &Acquisition{
+		AcquireTime: time.Unix(0, state.AcquireTime),
+		ExpireTime:  time.Unix(0, state.ExpireTime),
+		Fence:       state.Fence,
+		Body:        state.Body,
+	}, nil
+}
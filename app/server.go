@@ -5,10 +5,21 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// maxWait caps how long an Acquire request can long-poll for a contended
+// lock, regardless of what the client asks for.
+const maxWait = 60 * time.Second
+
+// leaseDuration is how long an acquired lock is held before it expires
+// without a heartbeat.
+const leaseDuration = 30 * time.Second
+
 type server struct {
 	router  *mux.Router
 	backend Backend
@@ -35,8 +46,11 @@ func (s *server) routes() {
 		Methods("PUT", "POST")
 	s.router.HandleFunc("/locks/{key}/{fence}/heartbeat", s.HeartbeatHandler()).
 		Methods("POST")
-	s.router.HandleFunc("/locks/{key}", s.ReleaseHandler()).
+	s.router.HandleFunc("/locks/{key}/{fence}", s.ReleaseHandler()).
 		Methods("DELETE")
+	s.router.HandleFunc("/locks", s.AcquireMultiHandler()).
+		Methods("POST")
+	s.router.Handle("/metrics", promhttp.Handler())
 }
 
 func (s *server) handleIndex() http.HandlerFunc {
@@ -78,9 +92,25 @@ func (s *server) AcquireHandler() http.HandlerFunc {
 			w.Write([]byte(`{"error":"nonce longer than 64 bytes"}`))
 		}
 
-		acq, err := s.backend.Acquire(key, nonce, leaseDuration)
+		var wait time.Duration
+		if waitStr := r.URL.Query().Get("wait"); waitStr != "" {
+			var err error
+			wait, err = time.ParseDuration(waitStr)
+			if err != nil {
+				w.WriteHeader(400)
+				w.Write([]byte(`{"error":"wait is not a valid duration"}`))
+				return
+			}
+			if wait > maxWait {
+				wait = maxWait
+			}
+		}
+
+		start := time.Now()
+		acq, err := s.backend.Acquire(r.Context(), key, nonce, leaseDuration, wait)
+		logRequest("acquire", key, 0, nonce, start, err)
 		if err != nil {
-			if _, ok := err.(ExpectedError); ok {
+			if _, ok := err.(*ExpectedError); ok {
 				w.WriteHeader(403)
 			} else {
 				w.WriteHeader(500)
@@ -95,6 +125,74 @@ func (s *server) AcquireHandler() http.HandlerFunc {
 	}
 }
 
+type acquireMultiRequest struct {
+	Keys  []string `json:"keys"`
+	Nonce string   `json:"nonce"`
+}
+
+// AcquireMultiHandler acquires every key listed in the request body or none
+// of them, so a caller that needs several resources at once never ends up
+// holding only some of them.
+func (s *server) AcquireMultiHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("content-type", "application/json")
+
+		var req acquireMultiRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"could not decode request body"}`))
+			return
+		}
+
+		if len(req.Keys) == 0 {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"keys missing or empty"}`))
+			return
+		}
+		seen := make(map[string]bool, len(req.Keys))
+		for _, key := range req.Keys {
+			if key == "" {
+				w.WriteHeader(400)
+				w.Write([]byte(`{"error":"keys must not contain an empty key"}`))
+				return
+			}
+			if seen[key] {
+				w.WriteHeader(400)
+				w.Write([]byte(`{"error":"keys must not contain duplicates"}`))
+				return
+			}
+			seen[key] = true
+		}
+		if req.Nonce == "" {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"nonce missing or empty"}`))
+			return
+		}
+		if len(req.Nonce) > 64 {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"nonce longer than 64 bytes"}`))
+			return
+		}
+
+		start := time.Now()
+		acqs, err := s.backend.AcquireMulti(r.Context(), req.Keys, req.Nonce, leaseDuration)
+		logRequest("acquire_multi", strings.Join(req.Keys, ","), 0, req.Nonce, start, err)
+		if err != nil {
+			if _, ok := err.(*ExpectedError); ok {
+				w.WriteHeader(403)
+			} else {
+				w.WriteHeader(500)
+			}
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(acqs)
+	}
+}
+
 func (s *server) UpdateValueHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("content-type", "application/json")
@@ -128,9 +226,11 @@ func (s *server) UpdateValueHandler() http.HandlerFunc {
 			return
 		}
 
-		err = s.backend.UpdateValue(key, fence, leaseDuration, string(valueBytes))
+		start := time.Now()
+		err = s.backend.UpdateValue(r.Context(), key, fence, leaseDuration, string(valueBytes))
+		logRequest("update", key, fence, "", start, err)
 		if err != nil {
-			if _, ok := err.(ExpectedError); ok {
+			if _, ok := err.(*ExpectedError); ok {
 				w.WriteHeader(403)
 			} else {
 				w.WriteHeader(500)
@@ -168,9 +268,11 @@ func (s *server) HeartbeatHandler() http.HandlerFunc {
 			return
 		}
 
-		err = s.backend.Heartbeat(key, fence, leaseDuration)
+		start := time.Now()
+		err = s.backend.Heartbeat(r.Context(), key, fence, leaseDuration)
+		logRequest("heartbeat", key, fence, "", start, err)
 		if err != nil {
-			if _, ok := err.(ExpectedError); ok {
+			if _, ok := err.(*ExpectedError); ok {
 				w.WriteHeader(403)
 			} else {
 				w.WriteHeader(500)
@@ -208,9 +310,11 @@ func (s *server) ReleaseHandler() http.HandlerFunc {
 			return
 		}
 
-		err = s.backend.Release(key, fence)
+		start := time.Now()
+		err = s.backend.Release(r.Context(), key, fence)
+		logRequest("release", key, fence, "", start, err)
 		if err != nil {
-			if _, ok := err.(ExpectedError); ok {
+			if _, ok := err.(*ExpectedError); ok {
 				w.WriteHeader(403)
 			} else {
 				w.WriteHeader(500)
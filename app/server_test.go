@@ -0,0 +1,90 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func acquire(t *testing.T, s *server, key string, nonce string) (int, *Acquisition) {
+	t.Helper()
+
+	req := httptest.NewRequest("PUT", "/locks/"+key+"?nonce="+nonce, nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	var acq Acquisition
+	if rec.Code == 200 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &acq); err != nil {
+			t.Fatalf("decoding acquire response: %v", err)
+		}
+	}
+	return rec.Code, &acq
+}
+
+func TestAcquireHandlerSuccess(t *testing.T) {
+	s := NewServer(NewMemoryBackend())
+
+	code, acq := acquire(t, s, "mykey", "nonce-a")
+	if code != 200 {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if acq.Fence != 1 {
+		t.Fatalf("expected fence 1, got %d", acq.Fence)
+	}
+}
+
+func TestAcquireHandlerContention(t *testing.T) {
+	s := NewServer(NewMemoryBackend())
+
+	if code, _ := acquire(t, s, "mykey", "nonce-a"); code != 200 {
+		t.Fatalf("expected first acquire to succeed, got %d", code)
+	}
+
+	code, _ := acquire(t, s, "mykey", "nonce-b")
+	if code != 403 {
+		t.Fatalf("expected contended acquire to return 403, got %d", code)
+	}
+}
+
+func TestHeartbeatHandler(t *testing.T) {
+	s := NewServer(NewMemoryBackend())
+
+	_, acq := acquire(t, s, "mykey", "nonce-a")
+
+	req := httptest.NewRequest("POST", "/locks/mykey/"+strconv.FormatInt(acq.Fence, 10)+"/heartbeat", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("expected heartbeat to return 200, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/locks/mykey/999/heartbeat", nil)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != 403 {
+		t.Fatalf("expected heartbeat with wrong fence to return 403, got %d", rec.Code)
+	}
+}
+
+func TestReleaseHandlerAllowsReacquire(t *testing.T) {
+	s := NewServer(NewMemoryBackend())
+
+	_, acq := acquire(t, s, "mykey", "nonce-a")
+
+	req := httptest.NewRequest("DELETE", "/locks/mykey/"+strconv.FormatInt(acq.Fence, 10), nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("expected release to return 200, got %d", rec.Code)
+	}
+
+	code, newAcq := acquire(t, s, "mykey", "nonce-b")
+	if code != 200 {
+		t.Fatalf("expected re-acquire after release to succeed, got %d", code)
+	}
+	if newAcq.Fence != acq.Fence+1 {
+		t.Fatalf("expected fence to advance after re-acquire, got %d", newAcq.Fence)
+	}
+}
@@ -2,40 +2,79 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
 	"github.com/twooster/glock/app"
+	"github.com/twooster/glock/glockpb"
 )
 
 var tableName = "Glock"
 
+func buildBackend(name string) app.Backend {
+	switch name {
+	case "redis":
+		return &app.RedisBackend{
+			Client: app.BuildRedisClient(),
+		}
+	case "dynamo":
+		return &app.DynamoBackend{
+			Db:    app.BuildDynamodbClient(),
+			Table: tableName,
+		}
+	case "memory":
+		return app.NewMemoryBackend()
+	default:
+		log.Fatalf("Unknown backend %q, expected one of: redis, dynamo, memory", name)
+		return nil
+	}
+}
+
 func main() {
+	backendFlag := flag.String("backend", "dynamo", "lock storage backend to use: redis|dynamo|memory")
+	flag.Parse()
+
 	rand.Seed(time.Now().UnixNano())
-	db := app.BuildDynamodbClient()
-	backend := app.DynamoBackend{
-		Db:    db,
-		Table: "Glock",
-	}
-	server := app.NewServer(&backend)
+	backend := app.NewInstrumentedBackend(buildBackend(*backendFlag))
+	server := app.NewServer(backend)
 
 	srv := &http.Server{
 		Addr:    ":12345",
 		Handler: server,
 	}
 
+	grpcServer := grpc.NewServer()
+	glockpb.RegisterGlockServer(grpcServer, app.NewGrpcServer(backend))
+
+	grpcListener, err := net.Listen("tcp", ":12346")
+	if err != nil {
+		log.Fatalf("Error listening for gRPC: %v\n", err)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	go func() {
-		fmt.Println("Starting HTTP server")
+		app.Logger.Info("Starting HTTP server", zap.String("addr", srv.Addr))
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			fmt.Printf("Error starting server: %v\n", err)
+			app.Logger.Error("Error starting HTTP server", zap.Error(err))
+		}
+		cancel()
+	}()
+
+	go func() {
+		app.Logger.Info("Starting gRPC server", zap.String("addr", grpcListener.Addr().String()))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			app.Logger.Error("Error starting gRPC server", zap.Error(err))
 		}
 		cancel()
 	}()
@@ -44,12 +83,13 @@ func main() {
 		stopChannel := make(chan os.Signal, 1)
 		signal.Notify(stopChannel, syscall.SIGTERM, syscall.SIGINT)
 		s := <-stopChannel
-		fmt.Printf("Received signal '%v', shutting down...\n", s)
+		app.Logger.Info("Received signal, shutting down", zap.String("signal", s.String()))
 		cancel()
 	}()
 
 	<-ctx.Done()
 	// We received an interrupt signal, shut down.
+	grpcServer.GracefulStop()
 	if err := srv.Shutdown(context.Background()); err != nil {
 		log.Printf("Error shutting down server: %v\n", err)
 	}